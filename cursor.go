@@ -0,0 +1,176 @@
+package pageable
+
+import (
+	"encoding/base64"
+	"fmt"
+	"gorm.io/gorm"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PageRequest describes a keyset ("cursor") pagination request, modelled on
+// the Cosmos-SDK PageRequest: callers pass either Offset or the opaque Key
+// returned by a previous PageResponse, never both.
+type PageRequest struct {
+	Offset     uint64 //Offset: raw SQL offset, only used when Key is empty
+	Limit      uint64 //Limit: max rows to return, defaultRpp is used when 0
+	Key        []byte //Key: opaque cursor returned as PageResponse.NextKey
+	CountTotal bool   //CountTotal: if true also run a COUNT(*) to fill PageResponse.Total
+	Reverse    bool   //Reverse: page from newest to oldest instead of oldest to newest
+}
+
+// PageResponse is the companion result of PageQueryCursor.
+type PageResponse struct {
+	NextKey []byte //NextKey: opaque cursor for the next page, nil once the last page is reached
+	Total   uint64 //Total: total row count, only populated when PageRequest.CountTotal is true
+}
+
+// commonInitialisms are the column segments keyColumnToField capitalizes
+// entirely instead of just title-casing, e.g. "id" -> "ID", not "Id". This is
+// a subset of the initialisms GORM's default naming strategy knows; a segment
+// not listed here (e.g. "ip") is only title-cased ("Ip").
+var commonInitialisms = map[string]bool{
+	"ID":   true,
+	"UID":  true,
+	"UUID": true,
+	"URL":  true,
+	"API":  true,
+	"HTTP": true,
+}
+
+// keyColumnToField maps a snake_case SQL column name to the Go field name
+// gorm would generate for it by default, e.g. "created_at" -> "CreatedAt",
+// "id" -> "ID", "user_id" -> "UserID".
+func keyColumnToField(keyColumn string) string {
+	parts := strings.Split(keyColumn, "_")
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		if upper := strings.ToUpper(part); commonInitialisms[upper] {
+			parts[i] = upper
+			continue
+		}
+		parts[i] = strings.ToUpper(part[:1]) + part[1:]
+	}
+	return strings.Join(parts, "")
+}
+
+// resultElemFieldType resolves the reflect.Type of the field fieldName maps
+// to on resultPtr's slice element type. It works off the static type alone,
+// so it can be used before any rows have been fetched.
+func resultElemFieldType(resultPtr interface{}, fieldName string) (reflect.Type, bool) {
+	elemType := reflect.TypeOf(resultPtr).Elem().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	field, ok := elemType.FieldByName(fieldName)
+	if !ok {
+		return nil, false
+	}
+	return field.Type, true
+}
+
+// convertKey parses raw (the base64-decoded cursor) into a value of
+// fieldType, so it binds against fieldType's SQL column using that column's
+// native type rather than as a string - Postgres in particular rejects
+// comparisons like `bigint > text` with no implicit cast.
+func convertKey(raw []byte, fieldType reflect.Type) (interface{}, error) {
+	s := string(raw)
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(s, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(s, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(s, 64)
+	default:
+		return s, nil
+	}
+}
+
+// PageQueryCursor performs keyset ("seek method") pagination on keyColumn,
+// avoiding the OFFSET degradation that PageQuery suffers on large tables.
+//
+// When req.Key is set it is base64-decoded into the last seen value of
+// keyColumn and used to build a `WHERE keyColumn > ?` bound (or `< ?` when
+// req.Reverse); otherwise req.Offset is used. One extra row beyond the
+// requested limit is fetched so PageResponse.NextKey can be derived without
+// a second round trip; once found that extra row is trimmed off resultPtr.
+//
+// resultPtr MUST be a pointer to a slice, the same requirement as PageQuery.
+//
+// resultPtr's element type MUST have a field matching keyColumn under GORM's
+// default naming strategy (e.g. keyColumn "user_id" needs a UserID field) -
+// PageQueryCursor returns an error rather than panicking if it can't find one.
+func PageQueryCursor(req *PageRequest, queryHandler *gorm.DB, keyColumn string, resultPtr interface{}) (*PageResponse, error) {
+	//recovery
+	defer recovery()
+
+	limit := req.Limit
+	if limit < 1 {
+		limit = uint64(defaultRpp)
+	}
+
+	order := keyColumn
+	cmp := ">"
+	if req.Reverse {
+		order += " DESC"
+		cmp = "<"
+	}
+
+	fieldName := keyColumnToField(keyColumn)
+	fieldType, ok := resultElemFieldType(resultPtr, fieldName)
+	if !ok {
+		return nil, fmt.Errorf("pageable: keyColumn %q has no matching field %q on %T", keyColumn, fieldName, resultPtr)
+	}
+
+	handler := queryHandler
+	if len(req.Key) > 0 {
+		lastSeen, err := base64.StdEncoding.DecodeString(string(req.Key))
+		if err != nil {
+			return nil, fmt.Errorf("pageable: invalid key: %w", err)
+		}
+		keyVal, err := convertKey(lastSeen, fieldType)
+		if err != nil {
+			return nil, fmt.Errorf("pageable: invalid key: %w", err)
+		}
+		handler = handler.Where(fmt.Sprintf("%s %s ?", keyColumn, cmp), keyVal)
+	} else if req.Offset > 0 {
+		handler = handler.Offset(int(req.Offset))
+	}
+
+	resp := &PageResponse{}
+	if req.CountTotal {
+		var total int64
+		if err := queryHandler.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, err
+		}
+		resp.Total = uint64(total)
+	}
+
+	if err := handler.Order(order).Limit(int(limit + 1)).Find(resultPtr).Error; err != nil {
+		return nil, err
+	}
+
+	// trim the extra row (if any); NextKey comes from the last row actually
+	// returned on this page, not the trimmed-off overflow row - using the
+	// overflow row's key would permanently skip the row it belongs to, since
+	// it's excluded both from this page (trimmed) and the next (`> NextKey`)
+	rv := reflect.ValueOf(resultPtr).Elem()
+	hasNext := uint64(rv.Len()) > limit
+	if hasNext {
+		rv.Set(rv.Slice(0, int(limit)))
+	}
+	if hasNext && limit > 0 {
+		last := rv.Index(int(limit) - 1)
+		keyVal := last.FieldByName(fieldName)
+		if !keyVal.IsValid() {
+			return nil, fmt.Errorf("pageable: keyColumn %q has no matching field %q on %T", keyColumn, fieldName, resultPtr)
+		}
+		resp.NextKey = []byte(base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(keyVal.Interface()))))
+	}
+
+	return resp, nil
+}
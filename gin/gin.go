@@ -0,0 +1,30 @@
+// Package pageablegin adapts gorm-pageable's HTTP query-string parsing to
+// gin. It lives in its own module-free subpackage so importing the
+// framework-agnostic root package (github.com/BillSJC/gorm-pageable) never
+// pulls in github.com/gin-gonic/gin and its dependency tree.
+package pageablegin
+
+import (
+	"net/http"
+
+	pageable "github.com/BillSJC/gorm-pageable"
+	"github.com/gin-gonic/gin"
+)
+
+// PageParamsMiddleware returns a gin.HandlerFunc that parses `page`/`limit`
+// from the request's query string the same way pageable.ParseRequest does,
+// and stores the result on the gin.Context as "page" and "limit" for
+// downstream handlers (c.GetInt("page"), c.GetInt("limit")). A malformed
+// page/limit aborts the chain with 400.
+func PageParamsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, rpp, err := pageable.ParsePageParams(c.Query("page"), c.Query("limit"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Set("page", page)
+		c.Set("limit", rpp)
+		c.Next()
+	}
+}
@@ -0,0 +1,72 @@
+package pageable
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// MaxPageSizeLimit caps the rpp/limit accepted by ParseRequest and
+// PageParamsMiddleware, regardless of what the query string asks for.
+var MaxPageSizeLimit = 100
+
+// ParseRequest reads `page`/`limit` from r's URL query parameters, defaulting
+// limit to defaultRpp and clamping it to MaxPageSizeLimit. An absent page
+// defaults to 1 (or 0 if Use0AsFirstPage was called); an absent limit
+// defaults to defaultRpp. A present but non-integer page/limit is an error.
+func ParseRequest(r *http.Request) (page int, rpp int, err error) {
+	q := r.URL.Query()
+	return parseQuery(q.Get("page"), q.Get("limit"))
+}
+
+// ParsePageParams parses raw "page"/"limit" query string values the same
+// way ParseRequest does. It's exported so framework adapters that can't
+// import net/http's Request (e.g. gin's *gin.Context) can reuse the same
+// defaulting/clamping rules without duplicating them.
+func ParsePageParams(pageParam, limitParam string) (page int, rpp int, err error) {
+	return parseQuery(pageParam, limitParam)
+}
+
+// parseQuery (private) is the query-parameter parsing shared by ParseRequest
+// and ParsePageParams.
+func parseQuery(pageParam, limitParam string) (page int, rpp int, err error) {
+	page = 1
+	if use0Page {
+		page = 0
+	}
+	if pageParam != "" {
+		if page, err = strconv.Atoi(pageParam); err != nil {
+			return 0, 0, fmt.Errorf("pageable: invalid page %q", pageParam)
+		}
+	}
+
+	rpp = defaultRpp
+	if limitParam != "" {
+		if rpp, err = strconv.Atoi(limitParam); err != nil {
+			return 0, 0, fmt.Errorf("pageable: invalid limit %q", limitParam)
+		}
+	}
+	if rpp < 1 {
+		rpp = defaultRpp
+	}
+	if rpp > MaxPageSizeLimit {
+		rpp = MaxPageSizeLimit
+	}
+
+	return page, rpp, nil
+}
+
+// WriteResponse writes resp to w as the JSON envelope
+// `{"data": ..., "paginatorInfo": ...}` most frontends expect, sparing
+// callers from re-deriving a PaginatorInfo for every handler.
+func WriteResponse(w http.ResponseWriter, resp *Response) error {
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(struct {
+		Data          interface{}    `json:"data"`
+		PaginatorInfo *PaginatorInfo `json:"paginatorInfo"`
+	}{
+		Data:          resp.ResultSet,
+		PaginatorInfo: resp.PaginatorInfo(),
+	})
+}
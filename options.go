@@ -0,0 +1,156 @@
+package pageable
+
+import (
+	"gorm.io/gorm"
+	"reflect"
+)
+
+// PageQueryOpts configures optional behaviour for PageQueryWithOptions that
+// doesn't fit the plain PageQuery signature without breaking it.
+type PageQueryOpts struct {
+	Reverse    bool //Reverse: select the page window counting from the end of queryHandler's result set instead of the start
+	CountTotal bool //CountTotal: if false, skip the COUNT(*) PageQuery always runs and detect LastPage from a limit+1 probe instead. Ignored when Reverse is set, which always needs the count to compute its offset.
+}
+
+// PageQueryWithOptions is PageQuery plus a PageQueryOpts knob.
+//
+// When opts.Reverse is set, page 1 returns the LAST rawPerPage rows of
+// queryHandler's result set instead of the first rawPerPage rows, and
+// FirstPage/LastPage/StartRow/EndRow are computed from that end too - e.g.
+// with 5 rows ordered `score ASC` and rawPerPage 2, page 1 returns rows
+// {4,5} (still ordered ascending within the page), page 2 returns {2,3}.
+// This is a windowing change only: it does NOT reverse the row order within
+// a page, so if callers want rows newest-first they still need their own
+// `ORDER BY ... DESC` on queryHandler.
+//
+// When opts.CountTotal is false (and Reverse is not set), the COUNT(*)
+// PageQuery always runs is skipped: limit+1 rows are fetched instead, the
+// extra row (if any) is trimmed off resultPtr, and its presence is used to
+// derive LastPage. RawCount/PageCount are left at 0 in that case.
+func PageQueryWithOptions(page int, rawPerPage int, opts PageQueryOpts, queryHandler *gorm.DB, resultPtr interface{}) (*Response, error) {
+	if !opts.Reverse && opts.CountTotal {
+		return PageQuery(page, rawPerPage, queryHandler, resultPtr)
+	}
+	if !opts.Reverse {
+		return pageQueryNoCount(page, rawPerPage, queryHandler, resultPtr)
+	}
+
+	//recovery
+	defer recovery()
+
+	var count64 int64
+	queryHandler.Count(&count64)
+	count := int(count64)
+
+	rpp := rawPerPage
+	if rpp < 1 {
+		rpp = defaultRpp
+	}
+
+	// p : 0-indexed distance of the requested page from the newest row
+	p := page
+	if !use0Page {
+		p = page - 1
+	}
+
+	pageCount := count / rpp
+	if count%rpp != 0 {
+		pageCount++
+	}
+
+	// fetch the rpp rows immediately before offset+limit == count, i.e.
+	// counting backwards from the newest row instead of forwards from page 1
+	limit, offset := rpp, count-(p+1)*rpp
+	if offset < 0 {
+		limit += offset
+		offset = 0
+	}
+	if limit < 0 {
+		limit = 0
+	}
+
+	queryHandler.Limit(limit).Offset(offset).Find(resultPtr)
+	if err := queryHandler.Error; err != nil {
+		return nil, err
+	}
+
+	firstPage := page == 1
+	if use0Page {
+		firstPage = page == 0
+	}
+	lastPage := page == pageCount
+	empty := (page > pageCount) || count == 0
+
+	startRow, endRow := 0, 0
+	if !empty {
+		startRow = offset + 1
+		endRow = offset + limit
+	}
+
+	return &Response{
+		PageNow:    page,
+		PageCount:  pageCount,
+		RawPerPage: rawPerPage,
+		RawCount:   count,
+		ResultSet:  resultPtr,
+		FirstPage:  firstPage,
+		LastPage:   lastPage,
+		Empty:      empty,
+		StartRow:   startRow,
+		EndRow:     endRow,
+		handler:    queryHandler,
+		countKnown: true,
+	}, nil
+}
+
+// pageQueryNoCount is PageQuery without the COUNT(*): it fetches limit+1
+// rows, trims the extra one off resultPtr if present, and uses its presence
+// as the LastPage signal instead of comparing against a known row count.
+func pageQueryNoCount(page int, rawPerPage int, queryHandler *gorm.DB, resultPtr interface{}) (*Response, error) {
+	//recovery
+	defer recovery()
+
+	var limit, offset int
+	if !use0Page {
+		limit, offset = getLimitOffset(page-1, rawPerPage)
+	} else {
+		limit, offset = getLimitOffset(page, rawPerPage)
+	}
+
+	queryHandler.Limit(limit + 1).Offset(offset).Find(resultPtr)
+	if err := queryHandler.Error; err != nil {
+		return nil, err
+	}
+
+	rv := reflect.ValueOf(resultPtr).Elem()
+	lastPage := true
+	if rv.Len() > limit {
+		rv.Set(rv.Slice(0, limit))
+		lastPage = false
+	}
+
+	firstPage := page == 1
+	if use0Page {
+		firstPage = page == 0
+	}
+	empty := rv.Len() == 0
+
+	startRow, endRow := 0, 0
+	if !empty {
+		startRow = offset + 1
+		endRow = offset + rv.Len()
+	}
+
+	return &Response{
+		PageNow:    page,
+		RawPerPage: rawPerPage,
+		ResultSet:  resultPtr,
+		FirstPage:  firstPage,
+		LastPage:   lastPage,
+		Empty:      empty,
+		StartRow:   startRow,
+		EndRow:     endRow,
+		handler:    queryHandler,
+		countKnown: false,
+	}, nil
+}
@@ -1,26 +1,75 @@
 package pageable
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"gorm.io/gorm"
+	"reflect"
 	"runtime/debug"
 	"time"
 )
 
 // Response Base response of query
 type Response struct {
-	PageNow    int         //PageNow: current page of query
-	PageCount  int         //PageCount: total page of the query
-	RawCount   int         //RawCount: total raw of query
-	RawPerPage int         //RawPerPage: rpp
-	ResultSet  interface{} //ResultSet: result data
-	FirstPage  bool        //FirstPage: if the result is the first page
-	LastPage   bool        //LastPage: if the result is the last page
-	Empty      bool        //Empty: if the result is empty
-	StartRow   int         //The number of first record the the resultSet
-	EndRow     int         //The number of last record the the resultSet
-	handler    *gorm.DB    //the handler of gorm Query
+	PageNow    int         `json:"pageNow"`    //PageNow: current page of query
+	PageCount  int         `json:"pageCount"`  //PageCount: total page of the query
+	RawCount   int         `json:"rawCount"`   //RawCount: total raw of query
+	RawPerPage int         `json:"rawPerPage"` //RawPerPage: rpp
+	ResultSet  interface{} `json:"resultSet"`  //ResultSet: result data
+	FirstPage  bool        `json:"firstPage"`  //FirstPage: if the result is the first page
+	LastPage   bool        `json:"lastPage"`   //LastPage: if the result is the last page
+	Empty      bool        `json:"empty"`      //Empty: if the result is empty
+	StartRow   int         `json:"startRow"`   //The number of first record the the resultSet
+	EndRow     int         `json:"endRow"`     //The number of last record the the resultSet
+	handler    *gorm.DB    `json:"-"`          //the handler of gorm Query
+	countKnown bool        `json:"-"`          //countKnown: if true, RawCount/PageCount were computed, navigation can reuse them instead of re-running COUNT(*)
+}
+
+// PaginatorInfo is the frontend-friendly counterpart of Response's
+// Go-oriented fields, using the Count/CurrentPage/PerPage/Total naming
+// frontends commonly expect from a paginator.
+type PaginatorInfo struct {
+	Count        int  `json:"count"`        //Count: rows in this page
+	CurrentPage  int  `json:"currentPage"`  //CurrentPage: PageNow
+	PerPage      int  `json:"perPage"`      //PerPage: RawPerPage
+	Total        int  `json:"total"`        //Total: RawCount
+	LastPage     int  `json:"lastPage"`     //LastPage: PageCount, i.e. the number of the last page
+	FirstItem    int  `json:"firstItem"`    //FirstItem: StartRow
+	LastItem     int  `json:"lastItem"`     //LastItem: EndRow
+	HasMorePages bool `json:"hasMorePages"` //HasMorePages: true unless this is the last page
+}
+
+// PaginatorInfo derives a *PaginatorInfo from r.
+func (r *Response) PaginatorInfo() *PaginatorInfo {
+	count := 0
+	if rv := reflect.ValueOf(r.ResultSet); rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Elem().Kind() == reflect.Slice {
+		count = rv.Elem().Len()
+	}
+	return &PaginatorInfo{
+		Count:        count,
+		CurrentPage:  r.PageNow,
+		PerPage:      r.RawPerPage,
+		Total:        r.RawCount,
+		LastPage:     r.PageCount,
+		FirstItem:    r.StartRow,
+		LastItem:     r.EndRow,
+		HasMorePages: !r.LastPage,
+	}
+}
+
+// MarshalJSON implements json.Marshaler. It emits Response's own fields
+// alongside its derived PaginatorInfo and omits the internal handler, so a
+// *Response can be returned directly from an HTTP handler.
+func (r *Response) MarshalJSON() ([]byte, error) {
+	type alias Response
+	return json.Marshal(struct {
+		*alias
+		PaginatorInfo *PaginatorInfo `json:"paginatorInfo"`
+	}{
+		alias:         (*alias)(r),
+		PaginatorInfo: r.PaginatorInfo(),
+	})
 }
 
 // getLimitOffset (private) get LIMIT and OFFSET keyword in SQL
@@ -121,7 +170,17 @@ func PageQuery(page int, rawPerPage int, queryHandler *gorm.DB, resultPtr interf
 	//recovery
 	defer recovery()
 	var count64 int64
-	var count = 0
+	// get total count of the table
+	queryHandler.Count(&count64)
+	return pageQueryKnownCount(page, rawPerPage, int(count64), queryHandler, resultPtr)
+}
+
+// pageQueryKnownCount is PageQuery without the COUNT(*) round trip: count is
+// taken as already known (either just queried, or reused from a previous
+// Response) instead of being recomputed.
+func pageQueryKnownCount(page int, rawPerPage int, count int, queryHandler *gorm.DB, resultPtr interface{}) (*Response, error) {
+	//recovery
+	defer recovery()
 	// get limit and offSet
 	var limit, offset int
 	if !use0Page {
@@ -129,9 +188,6 @@ func PageQuery(page int, rawPerPage int, queryHandler *gorm.DB, resultPtr interf
 	} else {
 		limit, offset = getLimitOffset(page, rawPerPage)
 	}
-	// get total count of the table
-	queryHandler.Count(&count64)
-	count = int(count64)
 	// get result set by param
 	queryHandler.Limit(limit).Offset(offset).Find(resultPtr)
 	// handle DB error
@@ -143,11 +199,17 @@ func PageQuery(page int, rawPerPage int, queryHandler *gorm.DB, resultPtr interf
 	if count%rawPerPage != 0 {
 		PageCount++
 	}
+	// pageIndex : 0-indexed page number, regardless of use0Page
+	pageIndex := page
+	if !use0Page {
+		pageIndex = page - 1
+	}
 	startRow, endRow, empty, lastPage := 0, 0, (page > PageCount) || count == 0, page == PageCount
 	if !empty {
-		startRow = page * rawPerPage
+		// 1-based row numbers: e.g. page 1 of 10-rpp is rows 1-10, not 0-9
+		startRow = pageIndex*rawPerPage + 1
 		if !lastPage {
-			endRow = (page+1)*rawPerPage - 1
+			endRow = (pageIndex + 1) * rawPerPage
 		} else {
 			endRow = count
 		}
@@ -165,9 +227,18 @@ func PageQuery(page int, rawPerPage int, queryHandler *gorm.DB, resultPtr interf
 		StartRow:   startRow,
 		EndRow:     endRow,
 		handler:    queryHandler,
+		countKnown: true,
 	}, nil
 }
 
+// InvalidateCount marks r's RawCount/PageCount as stale, forcing the next
+// GetNextPage/GetLastPage/GetEndPage/GetFirstPage navigation to recompute
+// them with a fresh COUNT(*) instead of reusing the value already on r.
+// Call this after mutating the underlying table between navigations.
+func (r *Response) InvalidateCount() {
+	r.countKnown = false
+}
+
 // SetHandler once you want to change the query handler, you can do this to replace it
 // 		resp.SetHandler(DB.Model(&User{}).Where(&User{UserName:"john"}))	//set the handler
 func (r *Response) SetHandler(handler *gorm.DB) {
@@ -192,17 +263,17 @@ func (r *Response) SetHandler(handler *gorm.DB) {
 //		resp,err := resp.GetNextPage()	//Response of next page
 // 	}
 func (r *Response) GetNextPage() (*Response, error) {
-	return PageQuery(r.PageNow+1, r.RawPerPage, r.handler, r.ResultSet)
+	return r.navigate(r.PageNow + 1)
 }
 
 // GetLastPage return last page`s Response
 func (r *Response) GetLastPage() (*Response, error) {
-	return PageQuery(r.PageNow-1, r.RawPerPage, r.handler, r.ResultSet)
+	return r.navigate(r.PageNow - 1)
 }
 
 // GetEndPage return end page`s Response
 func (r *Response) GetEndPage() (*Response, error) {
-	return PageQuery(r.PageCount, r.RawPerPage, r.handler, r.ResultSet)
+	return r.navigate(r.PageCount)
 }
 
 // GetFirstPage return first page`s Response
@@ -211,5 +282,14 @@ func (r *Response) GetFirstPage() (*Response, error) {
 	if use0Page {
 		p = 0
 	}
-	return PageQuery(p, r.RawPerPage, r.handler, r.ResultSet)
+	return r.navigate(p)
+}
+
+// navigate (private) re-runs the query for page, reusing r.RawCount instead
+// of recomputing it with COUNT(*) unless InvalidateCount was called on r.
+func (r *Response) navigate(page int) (*Response, error) {
+	if r.countKnown {
+		return pageQueryKnownCount(page, r.RawPerPage, r.RawCount, r.handler, r.ResultSet)
+	}
+	return PageQuery(page, r.RawPerPage, r.handler, r.ResultSet)
 }
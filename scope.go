@@ -0,0 +1,79 @@
+package pageable
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+)
+
+// Paginate returns a GORM scope that applies the same page/rpp semantics as
+// PageQuery (including use0Page), letting callers compose pagination with
+// other scopes instead of giving up their query builder:
+// 	db.Scopes(pageable.Paginate(2, 25)).Find(&users)
+func Paginate(page, rpp int) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		var limit, offset int
+		if !use0Page {
+			limit, offset = getLimitOffset(page-1, rpp)
+		} else {
+			limit, offset = getLimitOffset(page, rpp)
+		}
+		return db.Limit(limit).Offset(offset)
+	}
+}
+
+// PaginateInto is Paginate plus the bookkeeping PageQuery normally returns:
+// applying the scope also runs a COUNT(*) against the query state at that
+// point and fills page metadata into out, so callers composing pagination
+// via db.Scopes don't lose access to total-count / page info. out.ResultSet
+// is filled in once the caller's own Find/Scan actually runs - the scope
+// only sees *gorm.DB, not the destination the caller passes to Find, so a
+// one-shot "gorm:query" callback is the only hook available to capture it.
+// 	resp := &pageable.Response{}
+// 	db.Scopes(pageable.PaginateInto(2, 25, resp)).Find(&users)
+//
+// Note: the callback is registered on db's shared Config for the duration
+// between applying the scope and the next query db runs, so don't run other
+// queries concurrently on the same *gorm.DB handle in that window.
+func PaginateInto(page, rpp int, out *Response) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		var count64 int64
+		db.Session(&gorm.Session{}).Count(&count64)
+		count := int(count64)
+
+		rawPerPage := rpp
+		if rawPerPage < 1 {
+			rawPerPage = defaultRpp
+		}
+
+		pageCount := count / rawPerPage
+		if count%rawPerPage != 0 {
+			pageCount++
+		}
+
+		firstPage := page == 1
+		if use0Page {
+			firstPage = page == 0
+		}
+
+		*out = Response{
+			PageNow:    page,
+			PageCount:  pageCount,
+			RawPerPage: rawPerPage,
+			RawCount:   count,
+			FirstPage:  firstPage,
+			LastPage:   page == pageCount,
+			Empty:      (page > pageCount) || count == 0,
+			handler:    db,
+		}
+
+		scoped := db.Scopes(Paginate(page, rpp))
+
+		name := fmt.Sprintf("pageable:paginateInto:%p", out)
+		_ = scoped.Callback().Query().After("gorm:query").Register(name, func(tx *gorm.DB) {
+			out.ResultSet = tx.Statement.Dest
+			_ = tx.Callback().Query().Remove(name)
+		})
+
+		return scoped
+	}
+}